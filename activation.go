@@ -0,0 +1,153 @@
+package Neural
+
+import (
+	"math"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// Activation represents an activation function used by a layer of the
+// network. Apply computes the activation for a single pre-activation value
+// z, while Prime computes its derivative with respect to z.
+type Activation interface {
+	Apply(z float64) float64
+	Prime(z float64) float64
+}
+
+// Sigmoid maps a given value R:(-inf, +inf) to R:(0, 1).
+type Sigmoid struct{}
+
+// Apply implements Activation.
+func (Sigmoid) Apply(z float64) float64 {
+	return sigmoid(z)
+}
+
+// Prime implements Activation.
+func (Sigmoid) Prime(z float64) float64 {
+	s := sigmoid(z)
+	return s * (1 - s)
+}
+
+// Tanh maps a given value R:(-inf, +inf) to R:(-1, 1).
+type Tanh struct{}
+
+// Apply implements Activation.
+func (Tanh) Apply(z float64) float64 {
+	return math.Tanh(z)
+}
+
+// Prime implements Activation.
+func (Tanh) Prime(z float64) float64 {
+	t := math.Tanh(z)
+	return 1 - t*t
+}
+
+// ReLU is the rectified linear unit, max(0, z).
+type ReLU struct{}
+
+// Apply implements Activation.
+func (ReLU) Apply(z float64) float64 {
+	if z < 0 {
+		return 0
+	}
+	return z
+}
+
+// Prime implements Activation.
+func (ReLU) Prime(z float64) float64 {
+	if z < 0 {
+		return 0
+	}
+	return 1
+}
+
+// LeakyReLU is a ReLU variant that leaks a small, constant gradient
+// (Alpha) for negative inputs instead of zeroing them, which helps avoid
+// dead units. Alpha defaults to 0 if left unset, which makes it behave
+// like a plain ReLU.
+type LeakyReLU struct {
+	Alpha float64
+}
+
+// Apply implements Activation.
+func (l LeakyReLU) Apply(z float64) float64 {
+	if z < 0 {
+		return l.Alpha * z
+	}
+	return z
+}
+
+// Prime implements Activation.
+func (l LeakyReLU) Prime(z float64) float64 {
+	if z < 0 {
+		return l.Alpha
+	}
+	return 1
+}
+
+// Softmax normalizes an entire output layer into a probability
+// distribution, so unlike the other activations it cannot be applied
+// element-wise: Apply only exponentiates z (it exists to satisfy
+// Activation, but is never what actually runs), and Network.FeedForward
+// and backpropBatch instead recognise layers using Softmax and route
+// them through applySoftmaxRows, which subtracts each row's max before
+// exponentiating so large logits don't overflow to +Inf and turn the
+// normalization step into a NaN.
+// Prime is not used in the gradient: Softmax is expected to be paired
+// with CrossEntropy or LogLikelihood, whose Delta already folds in the
+// Softmax derivative, so Prime simply returns 1 to stay a safe no-op if
+// called.
+type Softmax struct{}
+
+// Apply implements Activation.
+func (Softmax) Apply(z float64) float64 {
+	return math.Exp(z)
+}
+
+// Prime implements Activation.
+func (Softmax) Prime(z float64) float64 {
+	return 1
+}
+
+// activationMatrix adapts an Activation's Apply method to the signature
+// required by mat64.Dense.Apply.
+func activationMatrix(a Activation) func(_, _ int, v float64) float64 {
+	return func(_, _ int, v float64) float64 {
+		return a.Apply(v)
+	}
+}
+
+// activationPrimeMatrix adapts an Activation's Prime method to the
+// signature required by mat64.Dense.Apply.
+func activationPrimeMatrix(a Activation) func(_, _ int, v float64) float64 {
+	return func(_, _ int, v float64) float64 {
+		return a.Prime(v)
+	}
+}
+
+// applySoftmaxRows turns each row of z, taken as pre-activation values,
+// into a probability distribution in place. It subtracts the row's max
+// before exponentiating (the standard stabilized-softmax trick) so that
+// large logits don't overflow math.Exp to +Inf, which would otherwise
+// turn the normalization below into a 0/0 or Inf/Inf NaN.
+func applySoftmaxRows(z *mat64.Dense) {
+	rows, cols := z.Dims()
+	for i := 0; i < rows; i++ {
+		max := z.At(i, 0)
+		for j := 1; j < cols; j++ {
+			if v := z.At(i, j); v > max {
+				max = v
+			}
+		}
+
+		sum := 0.0
+		for j := 0; j < cols; j++ {
+			e := math.Exp(z.At(i, j) - max)
+			z.Set(i, j, e)
+			sum += e
+		}
+		for j := 0; j < cols; j++ {
+			z.Set(i, j, z.At(i, j)/sum)
+		}
+	}
+}
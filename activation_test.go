@@ -0,0 +1,94 @@
+package Neural
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+func TestSigmoidApplyPrime(t *testing.T) {
+	// sigmoid(0) = 0.5, sigmoid'(0) = 0.5*(1-0.5) = 0.25
+	if got, want := (Sigmoid{}).Apply(0), 0.5; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Apply(0) = %v, want %v", got, want)
+	}
+	if got, want := (Sigmoid{}).Prime(0), 0.25; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Prime(0) = %v, want %v", got, want)
+	}
+}
+
+func TestTanhApplyPrime(t *testing.T) {
+	// z = ln(3): e^z = 3, e^-z = 1/3, so tanh(z) = (3 - 1/3)/(3 + 1/3) = 0.8
+	// tanh'(z) = 1 - 0.8^2 = 0.36
+	z := math.Log(3)
+	if got, want := (Tanh{}).Apply(z), 0.8; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Apply(%v) = %v, want %v", z, got, want)
+	}
+	if got, want := (Tanh{}).Prime(z), 0.36; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Prime(%v) = %v, want %v", z, got, want)
+	}
+}
+
+func TestReLUApplyPrime(t *testing.T) {
+	cases := []struct {
+		z, wantApply, wantPrime float64
+	}{
+		{-2, 0, 0},
+		{0, 0, 1},
+		{3, 3, 1},
+	}
+	for _, c := range cases {
+		if got := (ReLU{}).Apply(c.z); got != c.wantApply {
+			t.Errorf("Apply(%v) = %v, want %v", c.z, got, c.wantApply)
+		}
+		if got := (ReLU{}).Prime(c.z); got != c.wantPrime {
+			t.Errorf("Prime(%v) = %v, want %v", c.z, got, c.wantPrime)
+		}
+	}
+}
+
+func TestLeakyReLUApplyPrime(t *testing.T) {
+	l := LeakyReLU{Alpha: 0.1}
+	cases := []struct {
+		z, wantApply, wantPrime float64
+	}{
+		{-2, -0.2, 0.1},
+		{0, 0, 1},
+		{3, 3, 1},
+	}
+	for _, c := range cases {
+		if got := l.Apply(c.z); math.Abs(got-c.wantApply) > 1e-9 {
+			t.Errorf("Apply(%v) = %v, want %v", c.z, got, c.wantApply)
+		}
+		if got := l.Prime(c.z); math.Abs(got-c.wantPrime) > 1e-9 {
+			t.Errorf("Prime(%v) = %v, want %v", c.z, got, c.wantPrime)
+		}
+	}
+}
+
+func TestSoftmaxLargeLogitsStable(t *testing.T) {
+	net := Network{}
+	net.Init([]int{2, 3})
+	net.Activations[0] = Softmax{}
+
+	// Large enough to overflow math.Exp if not stabilized by subtracting
+	// the row max first.
+	net.Weights[0] = mat64.NewDense(2, 3, []float64{1000, 0, 0, 1000, 0, 0})
+	net.Biases[0] = mat64.NewDense(1, 3, nil)
+
+	input := mat64.NewDense(1, 2, []float64{1, 1})
+	output := net.FeedForward(input)
+
+	sum := 0.0
+	_, cols := output.Dims()
+	for j := 0; j < cols; j++ {
+		v := output.At(0, j)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			t.Fatalf("FeedForward output is not finite: %v", output)
+		}
+		sum += v
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("softmax row sum = %v, want 1", sum)
+	}
+}
@@ -0,0 +1,84 @@
+package Neural
+
+import (
+	"log"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// NetworkParams configures the network built by NewNetwork. A nil
+// Activations or Cost falls back to Init's defaults (Sigmoid for every
+// layer, Quadratic cost).
+type NetworkParams struct {
+	Activations []Activation
+	Cost        Cost
+}
+
+// NewNetwork builds and initializes a Network with the given layer
+// sizes (input, hidden..., output), applying params on top of Init's
+// defaults. It is the ergonomic entry point for callers who don't need
+// direct access to the lower-level Network fields.
+func NewNetwork(layers []int, params NetworkParams) *Network {
+	n := &Network{}
+	n.Init(layers)
+
+	if params.Activations != nil {
+		if len(params.Activations) != len(layers)-1 {
+			log.Fatal("NewNetwork: len(Activations) must equal len(layers)-1")
+		}
+		n.Activations = params.Activations
+	}
+	if params.Cost != nil {
+		n.Cost = params.Cost
+	}
+
+	return n
+}
+
+// TrainParams configures Train: TrainConfig carries the learning
+// knobs, and TestInputs/TestOutputs, if set, are used as the held-out
+// set for EpochCallback/EarlyStopPatience.
+type TrainParams struct {
+	TrainConfig
+	TestInputs, TestOutputs [][]float64
+}
+
+// Train fits the network to the given inputs/outputs, converting them
+// to the [][]mat64.Dense samples SGD expects so callers can work
+// directly with plain float64 slices.
+func (n *Network) Train(inputs, outputs [][]float64, params TrainParams) {
+	data := toDataset(inputs, outputs)
+
+	var test [][]mat64.Dense
+	if params.TestInputs != nil {
+		test = toDataset(params.TestInputs, params.TestOutputs)
+	}
+
+	n.SGD(data, params.TrainConfig, test)
+}
+
+// Predict feeds input through the network and returns the output layer
+// as a plain float64 slice.
+func (n *Network) Predict(input []float64) []float64 {
+	in := mat64.NewDense(1, len(input), input)
+	out := n.FeedForward(in)
+
+	_, cols := out.Dims()
+	result := make([]float64, cols)
+	for i := 0; i < cols; i++ {
+		result[i] = out.At(0, i)
+	}
+	return result
+}
+
+// toDataset pairs up inputs and outputs into the [][]mat64.Dense samples
+// used throughout this package: each entry is {input row, output row}.
+func toDataset(inputs, outputs [][]float64) [][]mat64.Dense {
+	data := make([][]mat64.Dense, len(inputs))
+	for i := range inputs {
+		data[i] = make([]mat64.Dense, 2)
+		data[i][0] = *mat64.NewDense(1, len(inputs[i]), inputs[i])
+		data[i][1] = *mat64.NewDense(1, len(outputs[i]), outputs[i])
+	}
+	return data
+}
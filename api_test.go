@@ -0,0 +1,19 @@
+package Neural
+
+import "testing"
+
+func TestTrainPredict(t *testing.T) {
+	net := NewNetwork([]int{2, 3, 1}, NetworkParams{})
+
+	inputs := [][]float64{{0, 0}, {0, 1}, {1, 0}, {1, 1}}
+	outputs := [][]float64{{0}, {1}, {1}, {0}}
+
+	net.Train(inputs, outputs, TrainParams{
+		TrainConfig: TrainConfig{Eta: 3, Epochs: 1, BatchSize: 2},
+	})
+
+	out := net.Predict(inputs[0])
+	if len(out) != 1 {
+		t.Errorf("Predict returned %v values, want 1", len(out))
+	}
+}
@@ -0,0 +1,107 @@
+package Neural
+
+import (
+	"math"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// Cost represents the cost (loss) function used to score how far the
+// network's output is from the desired output. Fn computes the scalar
+// cost over a batch, while Delta computes the error term for the output
+// layer that backpropagation starts from: dC/dz for that layer. Delta
+// receives the output layer's Activation so costs whose gradient needs
+// the real activation derivative (Quadratic) aren't stuck assuming one.
+type Cost interface {
+	Fn(output, desired *mat64.Dense) float64
+	Delta(z, output, desired *mat64.Dense, activation Activation) *mat64.Dense
+}
+
+// Quadratic is the mean-squared-error cost:
+// C = (1/2) * sum((output - desired) ^ 2).
+// Its Delta folds in the output layer's activation derivative, so it
+// works with whichever Activation the output layer is set to (Sigmoid,
+// Tanh, ReLU, ...).
+type Quadratic struct{}
+
+// Fn implements Cost.
+func (Quadratic) Fn(output, desired *mat64.Dense) float64 {
+	var diff mat64.Dense
+	diff.Sub(output, desired)
+	rows, cols := diff.Dims()
+	sum := 0.0
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			v := diff.At(i, j)
+			sum += v * v
+		}
+	}
+	return 0.5 * sum
+}
+
+// Delta implements Cost.
+func (Quadratic) Delta(z, output, desired *mat64.Dense, activation Activation) *mat64.Dense {
+	var delta, sp mat64.Dense
+	delta.Sub(output, desired)
+	sp.Apply(activationPrimeMatrix(activation), z)
+	delta.MulElem(&delta, &sp)
+	return &delta
+}
+
+// CrossEntropy is the cross-entropy cost:
+// C = -sum(y*ln(a) + (1-y)*ln(1-a)).
+// Paired with a Sigmoid output layer, its Delta simplifies to
+// (output - desired), which avoids the vanishing gradient that the
+// sigmoid derivative would otherwise introduce when a saturated neuron
+// is confidently wrong.
+type CrossEntropy struct{}
+
+// Fn implements Cost.
+func (CrossEntropy) Fn(output, desired *mat64.Dense) float64 {
+	rows, cols := output.Dims()
+	sum := 0.0
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			a, y := output.At(i, j), desired.At(i, j)
+			sum += -(y*math.Log(a) + (1-y)*math.Log(1-a))
+		}
+	}
+	return sum
+}
+
+// Delta implements Cost. activation is unused: the simplification below
+// only holds when the output layer's Activation is Sigmoid.
+func (CrossEntropy) Delta(z, output, desired *mat64.Dense, activation Activation) *mat64.Dense {
+	var delta mat64.Dense
+	delta.Sub(output, desired)
+	return &delta
+}
+
+// LogLikelihood is the negative log-likelihood cost:
+// C = -sum(y*ln(a)). It is intended for a Softmax output layer, where
+// its Delta, like CrossEntropy's, simplifies to (output - desired).
+type LogLikelihood struct{}
+
+// Fn implements Cost.
+func (LogLikelihood) Fn(output, desired *mat64.Dense) float64 {
+	rows, cols := output.Dims()
+	sum := 0.0
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			y := desired.At(i, j)
+			if y == 0 {
+				continue
+			}
+			sum += -y * math.Log(output.At(i, j))
+		}
+	}
+	return sum
+}
+
+// Delta implements Cost. activation is unused: the simplification below
+// only holds when the output layer's Activation is Softmax.
+func (LogLikelihood) Delta(z, output, desired *mat64.Dense, activation Activation) *mat64.Dense {
+	var delta mat64.Dense
+	delta.Sub(output, desired)
+	return &delta
+}
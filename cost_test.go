@@ -0,0 +1,94 @@
+package Neural
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+func TestQuadraticFn(t *testing.T) {
+	output := mat64.NewDense(1, 1, []float64{2})
+	desired := mat64.NewDense(1, 1, []float64{1})
+
+	// C = 0.5*(2-1)^2 = 0.5
+	if got, want := (Quadratic{}).Fn(output, desired), 0.5; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Fn() = %v, want %v", got, want)
+	}
+}
+
+func TestQuadraticDeltaUsesGivenActivation(t *testing.T) {
+	z := mat64.NewDense(1, 1, []float64{0})
+	output := mat64.NewDense(1, 1, []float64{2})
+	desired := mat64.NewDense(1, 1, []float64{1})
+
+	// delta = (output-desired) * activation.Prime(z)
+	// Sigmoid.Prime(0) = 0.25, so delta = 1*0.25 = 0.25
+	got := (Quadratic{}).Delta(z, output, desired, Sigmoid{})
+	if v := got.At(0, 0); math.Abs(v-0.25) > 1e-9 {
+		t.Errorf("Delta() with Sigmoid = %v, want 0.25", v)
+	}
+
+	// A Tanh output layer has a different derivative at z=0: Tanh.Prime(0) = 1,
+	// so delta = 1*1 = 1. Before Cost.Delta took the output layer's
+	// Activation, Quadratic always used the sigmoid derivative here, which
+	// would have silently returned 0.25 instead.
+	got = (Quadratic{}).Delta(z, output, desired, Tanh{})
+	if v := got.At(0, 0); math.Abs(v-1) > 1e-9 {
+		t.Errorf("Delta() with Tanh = %v, want 1", v)
+	}
+}
+
+func TestCrossEntropyFn(t *testing.T) {
+	output := mat64.NewDense(1, 1, []float64{0.5})
+	desired := mat64.NewDense(1, 1, []float64{1})
+
+	// C = -(1*ln(0.5) + 0*ln(0.5)) = -ln(0.5)
+	want := -math.Log(0.5)
+	if got := (CrossEntropy{}).Fn(output, desired); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Fn() = %v, want %v", got, want)
+	}
+}
+
+func TestCrossEntropyDeltaIgnoresActivation(t *testing.T) {
+	z := mat64.NewDense(1, 1, []float64{0})
+	output := mat64.NewDense(1, 1, []float64{0.5})
+	desired := mat64.NewDense(1, 1, []float64{1})
+
+	// delta = output - desired = -0.5, regardless of the activation passed
+	// in: the simplification only holds when paired with Sigmoid, so the
+	// parameter is accepted but unused.
+	for _, a := range []Activation{Sigmoid{}, Tanh{}} {
+		got := (CrossEntropy{}).Delta(z, output, desired, a)
+		if v := got.At(0, 0); math.Abs(v-(-0.5)) > 1e-9 {
+			t.Errorf("Delta() with %T = %v, want -0.5", a, v)
+		}
+	}
+}
+
+func TestLogLikelihoodFn(t *testing.T) {
+	output := mat64.NewDense(1, 2, []float64{0.3, 0.7})
+	desired := mat64.NewDense(1, 2, []float64{0, 1})
+
+	// C = -(1*ln(0.7)) (the y=0 term is skipped)
+	want := -math.Log(0.7)
+	if got := (LogLikelihood{}).Fn(output, desired); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Fn() = %v, want %v", got, want)
+	}
+}
+
+func TestLogLikelihoodDeltaIgnoresActivation(t *testing.T) {
+	z := mat64.NewDense(1, 2, []float64{0, 0})
+	output := mat64.NewDense(1, 2, []float64{0.3, 0.7})
+	desired := mat64.NewDense(1, 2, []float64{0, 1})
+
+	// delta = output - desired, regardless of the activation passed in: the
+	// simplification only holds when paired with Softmax.
+	got := (LogLikelihood{}).Delta(z, output, desired, Softmax{})
+	want := []float64{0.3, -0.3}
+	for j, w := range want {
+		if v := got.At(0, j); math.Abs(v-w) > 1e-9 {
+			t.Errorf("Delta()[%d] = %v, want %v", j, v, w)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package Neural
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// LoadCSV reads the CSV file at path and splits each row into an input
+// row (the columns at inputCols) and an output row (the columns at
+// outputCols), in the order given. It assumes the file has no header
+// row; callers that do should skip it themselves before passing columns
+// indices that account for it, or strip it from the file.
+func LoadCSV(path string, inputCols, outputCols []int) (inputs, outputs [][]float64, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inputs = make([][]float64, len(records))
+	outputs = make([][]float64, len(records))
+	for i, record := range records {
+		inputs[i], err = selectFloats(record, inputCols)
+		if err != nil {
+			return nil, nil, fmt.Errorf("row %d: %v", i, err)
+		}
+		outputs[i], err = selectFloats(record, outputCols)
+		if err != nil {
+			return nil, nil, fmt.Errorf("row %d: %v", i, err)
+		}
+	}
+	return inputs, outputs, nil
+}
+
+// selectFloats parses the given columns of record as float64s, in order.
+func selectFloats(record []string, cols []int) ([]float64, error) {
+	values := make([]float64, len(cols))
+	for i, col := range cols {
+		if col < 0 || col >= len(record) {
+			return nil, fmt.Errorf("column index %d out of range for row with %d columns", col, len(record))
+		}
+		v, err := strconv.ParseFloat(record[col], 64)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// OneHot encodes class as a one-hot row of length numClasses, with a 1
+// at index class and 0 elsewhere.
+func OneHot(class, numClasses int) []float64 {
+	row := make([]float64, numClasses)
+	row[class] = 1
+	return row
+}
+
+// OneHotEncode encodes a column of integer class labels into one-hot
+// rows suitable for use as Network outputs.
+func OneHotEncode(classes []int, numClasses int) [][]float64 {
+	rows := make([][]float64, len(classes))
+	for i, class := range classes {
+		rows[i] = OneHot(class, numClasses)
+	}
+	return rows
+}
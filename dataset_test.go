@@ -0,0 +1,63 @@
+package Neural
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLoadCSV(t *testing.T) {
+	file, err := os.CreateTemp("", "neural-dataset-*.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString("1,2,0\n3,4,1\n"); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	inputs, outputs, err := LoadCSV(file.Name(), []int{0, 1}, []int{2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]float64{{1, 2}, {3, 4}}
+	for i := range want {
+		if inputs[i][0] != want[i][0] || inputs[i][1] != want[i][1] {
+			t.Errorf("inputs[%d] = %v, want %v", i, inputs[i], want[i])
+		}
+	}
+	if outputs[0][0] != 0 || outputs[1][0] != 1 {
+		t.Errorf("outputs = %v, want [[0] [1]]", outputs)
+	}
+}
+
+func TestLoadCSVOutOfRangeColumn(t *testing.T) {
+	file, err := os.CreateTemp("", "neural-dataset-*.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString("1,2\n"); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	if _, _, err := LoadCSV(file.Name(), []int{0, 1}, []int{5}); err == nil {
+		t.Error("LoadCSV did not return an error for an out-of-range output column")
+	}
+}
+
+func TestOneHotEncode(t *testing.T) {
+	rows := OneHotEncode([]int{0, 2}, 3)
+	want := [][]float64{{1, 0, 0}, {0, 0, 1}}
+	for i := range want {
+		for j := range want[i] {
+			if rows[i][j] != want[i][j] {
+				t.Errorf("OneHotEncode()[%d] = %v, want %v", i, rows[i], want[i])
+			}
+		}
+	}
+}
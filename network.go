@@ -5,7 +5,6 @@ import (
 	"log"
 	"math"
 	"math/rand"
-	"os"
 
 	"github.com/gonum/matrix/mat64"
 )
@@ -13,16 +12,15 @@ import (
 // Network represents the neural network with its sizes, layers,
 // weights, Biases and cost function.
 type Network struct {
-	NumLayers int
-	Sizes     *mat64.Dense
-	Weights   []*mat64.Dense
-	Biases    []*mat64.Dense
-	TestFunc  TestCompFunc
+	NumLayers   int
+	Sizes       *mat64.Dense
+	Weights     []*mat64.Dense
+	Biases      []*mat64.Dense
+	TestFunc    TestCompFunc
+	Activations []Activation
+	Cost        Cost
 }
 
-var sizeFloat64 = 8
-var sizeInt64 = 8
-
 // TestCompFunc is to be defined by the user, here the user gets the output from
 // the Neural Network and the desired output. The user needs to return if the
 // output is acceptable or not.
@@ -45,6 +43,7 @@ func (n *Network) Init(size []int) {
 	// Set Weights and Biases to random values.
 	n.Weights = make([]*mat64.Dense, n.NumLayers-1)
 	n.Biases = make([]*mat64.Dense, n.NumLayers-1)
+	n.Activations = make([]Activation, n.NumLayers-1)
 	for i := 0; i < n.NumLayers-1; i++ {
 		n.Weights[i] = mat64.NewDense(size[i], size[i+1], nil)
 		for j := 0; j < size[i]; j++ {
@@ -57,55 +56,13 @@ func (n *Network) Init(size []int) {
 		for k := 0; k < size[i+1]; k++ {
 			n.Biases[i].Set(0, k, rand.NormFloat64())
 		}
-	}
 
-	// Set default TestCompfunc
-	n.TestFunc = nil
-}
-
-// DumpWeightsBiases dumps the weights and biases of the network onto
-// a given file.
-func (n *Network) DumpWeightsBiases(fileName string) {
-	file, err := os.OpenFile(fileName, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
-	defer file.Close()
-	if err != nil {
-		log.Fatal(err)
-	}
-	for i := range n.Weights {
-		data, err := n.Weights[i].MarshalBinary()
-		if err != nil {
-			log.Fatal(err)
-		}
-		file.Write(data)
-		data, err = n.Biases[i].MarshalBinary()
-		if err != nil {
-			log.Fatal(err)
-		}
-		file.Write(data)
+		n.Activations[i] = Sigmoid{}
 	}
-}
 
-// ReadWeightsBiases reads weights and biases and sets it onto the network.
-// complimentary to DumpWeightsBiases.
-func (n *Network) ReadWeightsBiases(fileName string) {
-	file, err := os.OpenFile(fileName, os.O_RDONLY, 0644)
-	defer file.Close()
-	if err != nil {
-		log.Fatal(err)
-	}
-	for i := range n.Weights {
-		x, y := n.Weights[i].Caps()
-		data := make([]byte, x*y*sizeFloat64+2*sizeInt64)
-		file.Read(data)
-		n.Weights[i].Reset()
-		n.Weights[i].UnmarshalBinary(data)
-
-		x, y = n.Biases[i].Caps()
-		data = make([]byte, x*y*sizeFloat64+2*sizeInt64)
-		file.Read(data)
-		n.Biases[i].Reset()
-		n.Biases[i].UnmarshalBinary(data)
-	}
+	// Set default TestCompfunc and Cost function.
+	n.TestFunc = nil
+	n.Cost = Quadratic{}
 }
 
 // FeedForward propagates the input through the network and returns the output.
@@ -117,163 +74,245 @@ func (n *Network) FeedForward(input *mat64.Dense) mat64.Dense {
 		var w mat64.Dense
 		w.Mul(ptr, n.Weights[i])
 		w.Add(&w, n.Biases[i])
-		w.Apply(sigmoidMatrix, &w)
+		if _, ok := n.Activations[i].(Softmax); ok {
+			applySoftmaxRows(&w)
+		} else {
+			w.Apply(activationMatrix(n.Activations[i]), &w)
+		}
 		ptr = &w
 	}
 	return *ptr
 }
 
-// SGD trains the neural network using the given set of inputs and outputs.
-// eta is the desired learning rate of the network.
-func (n *Network) SGD(data [][]mat64.Dense, eta float64, batchSize int, test [][]mat64.Dense) {
+// EpochCallback is invoked by SGD after every epoch with the epoch index
+// (0-based), the average training and validation cost, and the
+// validation accuracy as correct/total against the test set.
+type EpochCallback func(epoch int, trainLoss, valLoss float64, correct, total int)
+
+// SGD trains the neural network using the given set of inputs and
+// outputs, following the learning rate, regularization, optimizer and
+// epoch/batch sizing carried by cfg. Each epoch shuffles the training
+// data before slicing it into non-overlapping mini-batches, and, when
+// cfg.EpochCallback is set, reports progress against test once the
+// epoch's mini-batches have all been applied. If cfg.EarlyStopPatience
+// is positive, training stops once validation accuracy against test has
+// failed to improve for that many consecutive epochs.
+func (n *Network) SGD(data [][]mat64.Dense, cfg TrainConfig, test [][]mat64.Dense) {
 	if n.NumLayers < 2 {
 		log.Fatal("Network not set up")
 	}
-
-	iterations := len(data) / batchSize
-	if len(data)%batchSize > 0 {
-		iterations++
+	if cfg.Optimizer == nil {
+		cfg.Optimizer = VanillaSGD{}
 	}
 
 	size := n.NumLayers - 1
-	for i := 0; i < iterations; i++ {
-		nablaB := make([]mat64.Dense, size)
-		nablaW := make([]mat64.Dense, size)
-
-		for i := 0; i < size; i++ {
-			var x, y = int(n.Sizes.At(0, i)), int(n.Sizes.At(0, i+1))
-			nablaW[i] = *mat64.NewDense(x, y, nil)
-			nablaB[i] = *mat64.NewDense(1, y, nil)
+	trainSize := float64(len(data))
+
+	bestCorrect := -1
+	epochsSinceImprovement := 0
+
+	for epoch := 0; epoch < cfg.Epochs; epoch++ {
+		perm := rand.Perm(len(data))
+
+		for start := 0; start < len(perm); start += cfg.BatchSize {
+			end := start + cfg.BatchSize
+			if end > len(perm) {
+				end = len(perm)
+			}
+			batch := perm[start:end]
+
+			X := stackRows(data, batch, 0)
+			Y := stackRows(data, batch, 1)
+			nablaB, nablaW := n.backpropBatch(X, Y)
+
+			// Apply regularization (weight decay) and hand the
+			// averaged gradients off to the optimizer.
+			for i := 0; i < size; i++ {
+				n.regularize(n.Weights[i], cfg, trainSize)
+
+				var avgNablaB, avgNablaW mat64.Dense
+				avgNablaB.Scale(1/float64(len(batch)), &nablaB[i])
+				avgNablaW.Scale(1/float64(len(batch)), &nablaW[i])
+
+				cfg.Optimizer.Update(fmt.Sprintf("b%d", i), n.Biases[i], &avgNablaB, cfg.Eta)
+				cfg.Optimizer.Update(fmt.Sprintf("w%d", i), n.Weights[i], &avgNablaW, cfg.Eta)
+			}
 		}
 
-		if (len(data) - (i * batchSize)) < batchSize {
-			batchSize = len(data) - (i * batchSize)
+		if cfg.EpochCallback == nil && cfg.EarlyStopPatience <= 0 {
+			continue
 		}
 
-		for j := 0; j < batchSize; j++ {
-			tmpNablaB, tmpNablaW := n.backpropQuadCost(data[i+j], eta)
-			for k := 0; k < size; k++ {
-				nablaB[k].Add(&nablaB[k], &tmpNablaB[k])
-				nablaW[k].Add(&nablaW[k], &tmpNablaW[k])
-			}
+		trainLoss, _, _ := n.evaluate(data)
+		valLoss, correct, total := n.evaluate(test)
+
+		if cfg.EpochCallback != nil {
+			cfg.EpochCallback(epoch, trainLoss, valLoss, correct, total)
 		}
 
-		// Change the weights and biases of the network using the
-		// cost gradients obtained.
-		for i := 0; i < size; i++ {
-			x, y := nablaB[i].Caps()
-			for j := 0; j < x; j++ {
-				for k := 0; k < y; k++ {
-					n.Biases[i].Set(j, k, n.Biases[i].At(j, k)-nablaB[i].At(j, k)*eta/float64(batchSize))
-				}
-			}
-			x, y = nablaW[i].Caps()
-			for j := 0; j < x; j++ {
-				for k := 0; k < y; k++ {
-					n.Weights[i].Set(j, k, n.Weights[i].At(j, k)-nablaW[i].At(j, k)*eta/float64(batchSize))
+		if cfg.EarlyStopPatience > 0 {
+			if correct > bestCorrect {
+				bestCorrect = correct
+				epochsSinceImprovement = 0
+			} else {
+				epochsSinceImprovement++
+				if epochsSinceImprovement >= cfg.EarlyStopPatience {
+					break
 				}
 			}
 		}
 	}
-	if test != nil && n.TestFunc != nil {
-		correct := 0
-		testSize := len(test)
-		for i := 0; i < testSize; i++ {
-			op := n.FeedForward(&test[i][0])
-			if n.TestFunc(&op, &test[i][1]) {
-				correct++
-			}
+}
+
+// evaluate runs the network forward over dataset and reports the
+// average cost alongside TestFunc's accuracy count. dataset entries are
+// pairs of (input, desired output), as used throughout this package.
+func (n *Network) evaluate(dataset [][]mat64.Dense) (loss float64, correct, total int) {
+	total = len(dataset)
+	for i := 0; i < total; i++ {
+		output := n.FeedForward(&dataset[i][0])
+		if n.Cost != nil {
+			loss += n.Cost.Fn(&output, &dataset[i][1])
+		}
+		if n.TestFunc != nil && n.TestFunc(&output, &dataset[i][1]) {
+			correct++
 		}
-		fmt.Printf("Success : %v/%v\n", correct, testSize)
 	}
+	if total > 0 {
+		loss /= float64(total)
+	}
+	return loss, correct, total
 }
 
-// This cost function uses a quadratic method to derive the error.
-// error = (1/2) * (desiredOutput - output) ^ 2.
-// The derivative of this with respect to the desired output is what
-// we need. (i.e (output - desiredOutput)).
-func quadraticCost(output, desiredOutput *mat64.Dense) mat64.Dense {
-	var error mat64.Dense
-	error.Sub(output, desiredOutput)
-	return error
-}
+// regularize applies the weight decay selected by cfg.RegType to w in
+// place, ahead of the optimizer's gradient step.
+func (n *Network) regularize(w *mat64.Dense, cfg TrainConfig, trainSize float64) {
+	if cfg.RegType == NoReg || cfg.Lambda == 0 {
+		return
+	}
 
-// Using the given input and output (data) perform back-propagation and
-// return the cost gradients.
-func (n *Network) backpropQuadCost(data []mat64.Dense, eta float64) (nablaB, nablaW []mat64.Dense) {
-	if len(data) != 2 {
-		log.Fatal("Input and output data set mismatch")
+	decay := cfg.Eta * cfg.Lambda / trainSize
+	rows, cols := w.Dims()
+	for j := 0; j < rows; j++ {
+		for k := 0; k < cols; k++ {
+			switch cfg.RegType {
+			case L2:
+				w.Set(j, k, w.At(j, k)*(1-decay))
+			case L1:
+				w.Set(j, k, w.At(j, k)-decay*sign(w.At(j, k)))
+			}
+		}
 	}
+}
 
+// backpropBatch runs a single forward/backward pass over an entire
+// mini-batch at once: X is (batchSize x inputDim) and Y is
+// (batchSize x outputDim), each row one sample. Propagating the whole
+// batch through one Mul per layer, rather than backpropagating sample by
+// sample and summing the results, amortizes the per-call matrix
+// allocation and BLAS overhead across the batch.
+func (n *Network) backpropBatch(X, Y *mat64.Dense) (nablaB, nablaW []mat64.Dense) {
 	// If the no of layers in the network is 'n' then the number of
 	// connections is going to be 'n-1'. (i.e the no of weight/bias matrices).
 	size := n.NumLayers - 1
 
-	// Consider the input to be the first layer of the 'activations[]'. Hence
-	// always consider a '- 1' to the index of the 'activations[]' array.
+	// Consider the input batch to be the first layer of the
+	// 'activations[]'. Hence always consider a '- 1' to the index of the
+	// 'activations[]' array.
 	activations := make([]mat64.Dense, size+1)
 	zs := make([]mat64.Dense, size)
-	activations[0].Clone(&data[0])
+	activations[0] = *X
 
-	// Propagate the input through the layers of the network and
+	// Propagate the batch through the layers of the network and
 	// obtain the output for each layer before (zs[]) and after
 	// (activations[]) applying the activation function.
 	for i := 0; i < size; i++ {
 		activations[i+1].Mul(&activations[i], n.Weights[i])
-		activations[i+1].Add(&activations[i+1], n.Biases[i])
+		addBiasRows(&activations[i+1], n.Biases[i])
 		zs[i].Clone(&activations[i+1])
-		activations[i+1].Apply(sigmoidMatrix, &activations[i+1])
+		if _, ok := n.Activations[i].(Softmax); ok {
+			applySoftmaxRows(&activations[i+1])
+		} else {
+			activations[i+1].Apply(activationMatrix(n.Activations[i]), &activations[i+1])
+		}
 	}
 
-	// Create matrices which are similar to the weight and bias
-	// matrices, to hold cost gradients.
 	nablaW = make([]mat64.Dense, size)
 	nablaB = make([]mat64.Dense, size)
 
-	for i := 0; i < size; i++ {
-		nablaW[i].Clone(n.Weights[i])
-		nablaB[i].Clone(n.Biases[i])
-	}
-
-	var tmp, delta mat64.Dense
-
-	// Using the 'costFunction' obtain the gradients of the cost
-	// function for the outer most layer.
-	error := quadraticCost(&activations[size], &data[1])
-	tmp.Apply(sigmoidPrimeMatrix, &zs[size-1])
-	delta.MulElem(&error, &tmp)
+	// Using the network's Cost function obtain the gradients of the
+	// cost for the outer most layer, summed over the batch.
+	delta := *n.Cost.Delta(&zs[size-1], &activations[size], Y, n.Activations[size-1])
 
-	nablaB[size-1].Clone(&delta)
+	nablaB[size-1] = *colSum(&delta)
 	nablaW[size-1].Mul(activations[size-1].T(), &delta)
 
 	// Obtain the gradients of the cost for all other layers.
 	for i := size - 2; i >= 0; i-- {
 		var sp, tmp mat64.Dense
 
-		sp.Apply(sigmoidPrimeMatrix, &zs[i])
+		sp.Apply(activationPrimeMatrix(n.Activations[i]), &zs[i])
 		tmp.Mul(&delta, n.Weights[i+1].T())
 		delta.Reset()
 		delta.MulElem(&tmp, &sp)
-		nablaB[i] = delta
+		nablaB[i] = *colSum(&delta)
 		nablaW[i].Mul(activations[i].T(), &delta)
 	}
 
 	return nablaB, nablaW
 }
 
-// Wrapper function for mat64.Dense.Apply(...).
-// Applies the sigmoid function to each value of the matrix.
-func sigmoidMatrix(_, _ int, v float64) float64 {
-	return sigmoid(v)
+// stackRows builds a (len(idx) x cols) matrix out of dataset's col'th
+// row (0 for inputs, 1 for outputs) at each of the given sample indices,
+// so a mini-batch can be fed through backpropBatch as a single matrix.
+func stackRows(dataset [][]mat64.Dense, idx []int, col int) *mat64.Dense {
+	_, cols := dataset[idx[0]][col].Dims()
+	m := mat64.NewDense(len(idx), cols, nil)
+	for r, i := range idx {
+		for j := 0; j < cols; j++ {
+			m.Set(r, j, dataset[i][col].At(0, j))
+		}
+	}
+	return m
 }
 
-// Wrapper function for mat64.Dense.Apply(...).
-// Applies the sigmoid_prime function to each value of the matrix.
-func sigmoidPrimeMatrix(_, _ int, v float64) float64 {
-	return sigmoid(v) * (1 - sigmoid(v))
+// addBiasRows adds the (1 x cols) row bias to every row of z in place,
+// broadcasting it across the batch.
+func addBiasRows(z, bias *mat64.Dense) {
+	rows, cols := z.Dims()
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			z.Set(i, j, z.At(i, j)+bias.At(0, j))
+		}
+	}
+}
+
+// colSum sums m's rows into a single (1 x cols) row, used to reduce a
+// per-sample batched gradient down to the per-layer bias gradient.
+func colSum(m *mat64.Dense) *mat64.Dense {
+	rows, cols := m.Dims()
+	sum := mat64.NewDense(1, cols, nil)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			sum.Set(0, j, sum.At(0, j)+m.At(i, j))
+		}
+	}
+	return sum
 }
 
 // Sigmoid function maps a given value R:(-inf, +inf) to R:(0, 1).
 func sigmoid(z float64) float64 {
 	return 1.0 / (1.0 + math.Exp(-z))
 }
+
+// sign returns the sign of v, used by L1 regularization's subgradient.
+func sign(v float64) float64 {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -80,5 +80,123 @@ func TestSGD(t *testing.T) {
 		test[i][1] = *mat64.NewDense(1, 1, []float64{float64((i / 2) ^ (i % 2))})
 	}
 
-	net.SGD(data, eta, 3, test)
+	net.SGD(data, TrainConfig{Eta: eta, Epochs: 1, BatchSize: 3}, test)
+}
+
+func TestSGDEpochCallback(t *testing.T) {
+	var a = []int{2, 3, 1}
+	net := Network{}
+	net.Init(a)
+	net.TestFunc = func(output, desiredOutput *mat64.Dense) bool {
+		return math.Abs(output.At(0, 0)-desiredOutput.At(0, 0)) < 0.1
+	}
+
+	data := make([][]mat64.Dense, 8)
+	for i := 0; i < len(data); i++ {
+		x, y := i/4, (i/2)%2
+		data[i] = make([]mat64.Dense, 2)
+		data[i][0] = *mat64.NewDense(1, 2, []float64{float64(x), float64(y)})
+		data[i][1] = *mat64.NewDense(1, 1, []float64{float64(x ^ y)})
+	}
+
+	epochs := 3
+	seen := 0
+	net.SGD(data, TrainConfig{
+		Eta:       3,
+		Epochs:    epochs,
+		BatchSize: 4,
+		EpochCallback: func(epoch int, trainLoss, valLoss float64, correct, total int) {
+			if epoch != seen {
+				t.Errorf("EpochCallback called out of order: got %v, want %v", epoch, seen)
+			}
+			if total != len(data) {
+				t.Errorf("EpochCallback total = %v, want %v", total, len(data))
+			}
+			seen++
+		},
+	}, data)
+
+	if seen != epochs {
+		t.Errorf("EpochCallback invoked %v times, want %v", seen, epochs)
+	}
+}
+
+func TestRegularizeL2(t *testing.T) {
+	net := Network{}
+	w := mat64.NewDense(1, 1, []float64{2.0})
+
+	// decay = eta*lambda/trainSize = 0.1*5/100 = 0.005
+	// w = 2.0*(1-0.005) = 1.99
+	net.regularize(w, TrainConfig{Eta: 0.1, Lambda: 5, RegType: L2}, 100)
+
+	if got, want := w.At(0, 0), 1.99; math.Abs(got-want) > 1e-9 {
+		t.Errorf("w = %v, want %v", got, want)
+	}
+}
+
+func TestRegularizeL1(t *testing.T) {
+	net := Network{}
+	w := mat64.NewDense(1, 2, []float64{2.0, -2.0})
+
+	// decay = eta*lambda/trainSize = 0.1*5/100 = 0.005
+	// w[0] = 2.0 - 0.005*sign(2.0) = 1.995
+	// w[1] = -2.0 - 0.005*sign(-2.0) = -1.995
+	net.regularize(w, TrainConfig{Eta: 0.1, Lambda: 5, RegType: L1}, 100)
+
+	if got, want := w.At(0, 0), 1.995; math.Abs(got-want) > 1e-9 {
+		t.Errorf("w[0] = %v, want %v", got, want)
+	}
+	if got, want := w.At(0, 1), -1.995; math.Abs(got-want) > 1e-9 {
+		t.Errorf("w[1] = %v, want %v", got, want)
+	}
+}
+
+func TestBackpropBatchSumsPerSample(t *testing.T) {
+	var a = []int{2, 3, 1}
+	net := Network{}
+	net.Init(a)
+
+	data := [][]mat64.Dense{
+		{*mat64.NewDense(1, 2, []float64{0, 1}), *mat64.NewDense(1, 1, []float64{1})},
+		{*mat64.NewDense(1, 2, []float64{1, 0}), *mat64.NewDense(1, 1, []float64{1})},
+	}
+
+	size := net.NumLayers - 1
+	var wantNablaB, wantNablaW []mat64.Dense
+	for _, idx := range []int{0, 1} {
+		X := stackRows(data, []int{idx}, 0)
+		Y := stackRows(data, []int{idx}, 1)
+		nablaB, nablaW := net.backpropBatch(X, Y)
+		if wantNablaB == nil {
+			wantNablaB, wantNablaW = nablaB, nablaW
+			continue
+		}
+		for i := 0; i < size; i++ {
+			wantNablaB[i].Add(&wantNablaB[i], &nablaB[i])
+			wantNablaW[i].Add(&wantNablaW[i], &nablaW[i])
+		}
+	}
+
+	X := stackRows(data, []int{0, 1}, 0)
+	Y := stackRows(data, []int{0, 1}, 1)
+	gotNablaB, gotNablaW := net.backpropBatch(X, Y)
+
+	for i := 0; i < size; i++ {
+		rows, cols := wantNablaB[i].Dims()
+		for r := 0; r < rows; r++ {
+			for c := 0; c < cols; c++ {
+				if math.Abs(gotNablaB[i].At(r, c)-wantNablaB[i].At(r, c)) > 1e-9 {
+					t.Errorf("nablaB[%d].At(%d,%d) = %v, want %v", i, r, c, gotNablaB[i].At(r, c), wantNablaB[i].At(r, c))
+				}
+			}
+		}
+		rows, cols = wantNablaW[i].Dims()
+		for r := 0; r < rows; r++ {
+			for c := 0; c < cols; c++ {
+				if math.Abs(gotNablaW[i].At(r, c)-wantNablaW[i].At(r, c)) > 1e-9 {
+					t.Errorf("nablaW[%d].At(%d,%d) = %v, want %v", i, r, c, gotNablaW[i].At(r, c), wantNablaW[i].At(r, c))
+				}
+			}
+		}
+	}
 }
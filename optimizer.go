@@ -0,0 +1,163 @@
+package Neural
+
+import (
+	"math"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// Optimizer updates a parameter matrix in place given its averaged
+// gradient for the current mini-batch. id identifies the parameter
+// (e.g. "w0", "b1") so optimizers that keep per-parameter state, such as
+// Momentum and Adam, can track it across calls.
+type Optimizer interface {
+	Update(id string, param, grad *mat64.Dense, eta float64)
+}
+
+// VanillaSGD applies the plain gradient-descent update: w -= eta*grad.
+type VanillaSGD struct{}
+
+// Update implements Optimizer.
+func (VanillaSGD) Update(id string, param, grad *mat64.Dense, eta float64) {
+	rows, cols := param.Dims()
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			param.Set(i, j, param.At(i, j)-eta*grad.At(i, j))
+		}
+	}
+}
+
+// Momentum accumulates a velocity term that smooths the gradient across
+// mini-batches: v = mu*v - eta*grad; w += v.
+type Momentum struct {
+	Mu       float64
+	velocity map[string]*mat64.Dense
+}
+
+// NewMomentum creates a Momentum optimizer with the given momentum
+// coefficient mu.
+func NewMomentum(mu float64) *Momentum {
+	return &Momentum{Mu: mu, velocity: make(map[string]*mat64.Dense)}
+}
+
+// Update implements Optimizer.
+func (m *Momentum) Update(id string, param, grad *mat64.Dense, eta float64) {
+	v := m.velocityFor(id, grad)
+
+	var scaledGrad mat64.Dense
+	scaledGrad.Scale(eta, grad)
+	v.Scale(m.Mu, v)
+	v.Sub(v, &scaledGrad)
+
+	param.Add(param, v)
+}
+
+func (m *Momentum) velocityFor(id string, grad *mat64.Dense) *mat64.Dense {
+	v, ok := m.velocity[id]
+	if !ok {
+		rows, cols := grad.Dims()
+		v = mat64.NewDense(rows, cols, nil)
+		m.velocity[id] = v
+	}
+	return v
+}
+
+// NAG implements Nesterov Accelerated Gradient using the momentum-only
+// reformulation (Sutskever et al.), which folds the lookahead step into
+// the velocity update so it can be driven by the same gradient-at-current-
+// parameters that the rest of the package already computes:
+// v_prev = v; v = mu*v - eta*grad; w += -mu*v_prev + (1+mu)*v.
+type NAG struct {
+	Mu       float64
+	velocity map[string]*mat64.Dense
+}
+
+// NewNAG creates a NAG optimizer with the given momentum coefficient mu.
+func NewNAG(mu float64) *NAG {
+	return &NAG{Mu: mu, velocity: make(map[string]*mat64.Dense)}
+}
+
+// Update implements Optimizer.
+func (n *NAG) Update(id string, param, grad *mat64.Dense, eta float64) {
+	v, ok := n.velocity[id]
+	if !ok {
+		rows, cols := grad.Dims()
+		v = mat64.NewDense(rows, cols, nil)
+		n.velocity[id] = v
+	}
+	vPrev := mat64.DenseCopyOf(v)
+
+	var scaledGrad mat64.Dense
+	scaledGrad.Scale(eta, grad)
+	v.Scale(n.Mu, v)
+	v.Sub(v, &scaledGrad)
+
+	var a, b mat64.Dense
+	a.Scale(-n.Mu, vPrev)
+	b.Scale(1+n.Mu, v)
+	a.Add(&a, &b)
+
+	param.Add(param, &a)
+}
+
+// Adam keeps per-parameter, bias-corrected first and second moment
+// estimates of the gradient (Kingma & Ba).
+type Adam struct {
+	Beta1, Beta2, Eps float64
+	m, v              map[string]*mat64.Dense
+	t                 map[string]int
+}
+
+// NewAdam creates an Adam optimizer with the standard defaults of
+// beta1=0.9, beta2=0.999 and eps=1e-8.
+func NewAdam() *Adam {
+	return &Adam{
+		Beta1: 0.9,
+		Beta2: 0.999,
+		Eps:   1e-8,
+		m:     make(map[string]*mat64.Dense),
+		v:     make(map[string]*mat64.Dense),
+		t:     make(map[string]int),
+	}
+}
+
+// Update implements Optimizer.
+func (a *Adam) Update(id string, param, grad *mat64.Dense, eta float64) {
+	mm, ok := a.m[id]
+	if !ok {
+		rows, cols := grad.Dims()
+		mm = mat64.NewDense(rows, cols, nil)
+		a.m[id] = mm
+	}
+	vv, ok := a.v[id]
+	if !ok {
+		rows, cols := grad.Dims()
+		vv = mat64.NewDense(rows, cols, nil)
+		a.v[id] = vv
+	}
+	a.t[id]++
+	t := a.t[id]
+
+	var gradTerm mat64.Dense
+	gradTerm.Scale(1-a.Beta1, grad)
+	mm.Scale(a.Beta1, mm)
+	mm.Add(mm, &gradTerm)
+
+	var gradSq, gradSqTerm mat64.Dense
+	gradSq.MulElem(grad, grad)
+	gradSqTerm.Scale(1-a.Beta2, &gradSq)
+	vv.Scale(a.Beta2, vv)
+	vv.Add(vv, &gradSqTerm)
+
+	mHatScale := 1 / (1 - math.Pow(a.Beta1, float64(t)))
+	vHatScale := 1 / (1 - math.Pow(a.Beta2, float64(t)))
+
+	rows, cols := param.Dims()
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			mHat := mm.At(i, j) * mHatScale
+			vHat := vv.At(i, j) * vHatScale
+			param.Set(i, j, param.At(i, j)-eta*mHat/(math.Sqrt(vHat)+a.Eps))
+		}
+	}
+}
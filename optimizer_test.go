@@ -0,0 +1,71 @@
+package Neural
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+func TestVanillaSGDUpdate(t *testing.T) {
+	param := mat64.NewDense(1, 1, []float64{1.0})
+	grad := mat64.NewDense(1, 1, []float64{1.0})
+
+	VanillaSGD{}.Update("w", param, grad, 0.1)
+
+	if got, want := param.At(0, 0), 0.9; math.Abs(got-want) > 1e-9 {
+		t.Errorf("param = %v, want %v", got, want)
+	}
+}
+
+func TestMomentumUpdate(t *testing.T) {
+	param := mat64.NewDense(1, 1, []float64{1.0})
+	grad := mat64.NewDense(1, 1, []float64{1.0})
+	m := NewMomentum(0.9)
+
+	// v1 = 0.9*0 - 0.1*1 = -0.1; param = 1.0 - 0.1 = 0.9
+	m.Update("w", param, grad, 0.1)
+	if got, want := param.At(0, 0), 0.9; math.Abs(got-want) > 1e-9 {
+		t.Errorf("after step 1, param = %v, want %v", got, want)
+	}
+
+	// v2 = 0.9*(-0.1) - 0.1*1 = -0.19; param = 0.9 - 0.19 = 0.71
+	m.Update("w", param, grad, 0.1)
+	if got, want := param.At(0, 0), 0.71; math.Abs(got-want) > 1e-9 {
+		t.Errorf("after step 2, param = %v, want %v", got, want)
+	}
+}
+
+func TestNAGUpdate(t *testing.T) {
+	param := mat64.NewDense(1, 1, []float64{1.0})
+	grad := mat64.NewDense(1, 1, []float64{1.0})
+	nag := NewNAG(0.9)
+
+	// v1 = 0.9*0 - 0.1*1 = -0.1
+	// param += -0.9*0 + 1.9*(-0.1) = -0.19 => 0.81
+	nag.Update("w", param, grad, 0.1)
+	if got, want := param.At(0, 0), 0.81; math.Abs(got-want) > 1e-9 {
+		t.Errorf("after step 1, param = %v, want %v", got, want)
+	}
+
+	// v2 = 0.9*(-0.1) - 0.1*1 = -0.19
+	// param += -0.9*(-0.1) + 1.9*(-0.19) = 0.09 - 0.361 = -0.271 => 0.539
+	nag.Update("w", param, grad, 0.1)
+	if got, want := param.At(0, 0), 0.539; math.Abs(got-want) > 1e-9 {
+		t.Errorf("after step 2, param = %v, want %v", got, want)
+	}
+}
+
+func TestAdamUpdate(t *testing.T) {
+	param := mat64.NewDense(1, 1, []float64{1.0})
+	grad := mat64.NewDense(1, 1, []float64{1.0})
+	adam := NewAdam()
+
+	// m1 = 0.1*1 = 0.1, v1 = 0.001*1 = 0.001
+	// mHat = 0.1/(1-0.9) = 1.0, vHat = 0.001/(1-0.999) = 1.0
+	// param -= 0.1*1.0/(sqrt(1.0)+1e-8) ~= 0.1 => param ~= 0.9
+	adam.Update("w", param, grad, 0.1)
+	if got, want := param.At(0, 0), 0.9; math.Abs(got-want) > 1e-6 {
+		t.Errorf("after step 1, param = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,285 @@
+package Neural
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// magicBytes identifies a Save'd model file; Load rejects anything else.
+var magicBytes = [4]byte{'N', 'N', 'E', 'T'}
+
+// formatVersion is bumped whenever modelSnapshot's shape changes in a
+// way that breaks compatibility with older Save'd files.
+const formatVersion uint32 = 1
+
+// modelSnapshot is the self-describing, portable representation of a
+// Network used by both the gob (Save/Load) and JSON (SaveJSON/LoadJSON)
+// formats.
+type modelSnapshot struct {
+	Sizes       []int
+	Activations []activationSpec
+	Cost        string
+	Weights     [][]float64
+	Biases      [][]float64
+}
+
+// activationSpec identifies a layer's Activation by name, plus any
+// parameters it needs to be reconstructed (only LeakyReLU's Alpha, at
+// present).
+type activationSpec struct {
+	Type  string
+	Alpha float64
+}
+
+// Save writes a self-describing, versioned snapshot of the network to
+// w: magic bytes and format version, followed by a checksummed,
+// gob-encoded payload carrying the layer sizes, per-layer activations,
+// cost function and trained weights/biases.
+func (n *Network) Save(w io.Writer) error {
+	snap, err := n.snapshot()
+	if err != nil {
+		return err
+	}
+
+	var payload bytes.Buffer
+	if err := gob.NewEncoder(&payload).Encode(snap); err != nil {
+		return err
+	}
+	checksum := crc32.ChecksumIEEE(payload.Bytes())
+
+	if _, err := w.Write(magicBytes[:]); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, formatVersion); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, checksum); err != nil {
+		return err
+	}
+	_, err = w.Write(payload.Bytes())
+	return err
+}
+
+// Load reads a snapshot written by Save and reconstructs the full
+// Network, including its architecture, activations and cost function.
+func Load(r io.Reader) (*Network, error) {
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return nil, err
+	}
+	if magic != magicBytes {
+		return nil, fmt.Errorf("Neural: not a model file (bad magic bytes)")
+	}
+
+	var version uint32
+	if err := binary.Read(r, binary.BigEndian, &version); err != nil {
+		return nil, err
+	}
+	if version != formatVersion {
+		return nil, fmt.Errorf("Neural: unsupported model format version %d", version)
+	}
+
+	var checksum uint32
+	if err := binary.Read(r, binary.BigEndian, &checksum); err != nil {
+		return nil, err
+	}
+
+	payload, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if crc32.ChecksumIEEE(payload) != checksum {
+		return nil, fmt.Errorf("Neural: model checksum mismatch, file may be corrupt")
+	}
+
+	var snap modelSnapshot
+	if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&snap); err != nil {
+		return nil, err
+	}
+	return snap.toNetwork()
+}
+
+// SaveJSON writes a human-inspectable JSON snapshot of the network to w.
+func (n *Network) SaveJSON(w io.Writer) error {
+	snap, err := n.snapshot()
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(snap)
+}
+
+// LoadJSON reads a snapshot written by SaveJSON and reconstructs the
+// full Network.
+func LoadJSON(r io.Reader) (*Network, error) {
+	var snap modelSnapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return nil, err
+	}
+	return snap.toNetwork()
+}
+
+// snapshot captures n's architecture, activations, cost function and
+// trained parameters into a modelSnapshot.
+func (n *Network) snapshot() (modelSnapshot, error) {
+	sizes := make([]int, n.NumLayers)
+	for i := 0; i < n.NumLayers; i++ {
+		sizes[i] = int(n.Sizes.At(0, i))
+	}
+
+	activations := make([]activationSpec, len(n.Activations))
+	for i, a := range n.Activations {
+		spec, err := activationToSpec(a)
+		if err != nil {
+			return modelSnapshot{}, err
+		}
+		activations[i] = spec
+	}
+
+	cost, err := costToID(n.Cost)
+	if err != nil {
+		return modelSnapshot{}, err
+	}
+
+	weights := make([][]float64, len(n.Weights))
+	biases := make([][]float64, len(n.Biases))
+	for i := range n.Weights {
+		weights[i] = denseToFlat(n.Weights[i])
+		biases[i] = denseToFlat(n.Biases[i])
+	}
+
+	return modelSnapshot{
+		Sizes:       sizes,
+		Activations: activations,
+		Cost:        cost,
+		Weights:     weights,
+		Biases:      biases,
+	}, nil
+}
+
+// toNetwork reconstructs a Network from snap.
+func (snap modelSnapshot) toNetwork() (*Network, error) {
+	n := &Network{}
+	n.NumLayers = len(snap.Sizes)
+	n.Sizes = mat64.NewDense(1, n.NumLayers, nil)
+	for i, s := range snap.Sizes {
+		n.Sizes.Set(0, i, float64(s))
+	}
+
+	size := n.NumLayers - 1
+	n.Weights = make([]*mat64.Dense, size)
+	n.Biases = make([]*mat64.Dense, size)
+	n.Activations = make([]Activation, size)
+	for i := 0; i < size; i++ {
+		n.Weights[i] = flatToDense(snap.Weights[i], snap.Sizes[i], snap.Sizes[i+1])
+		n.Biases[i] = flatToDense(snap.Biases[i], 1, snap.Sizes[i+1])
+
+		a, err := specToActivation(snap.Activations[i])
+		if err != nil {
+			return nil, err
+		}
+		n.Activations[i] = a
+	}
+
+	cost, err := idToCost(snap.Cost)
+	if err != nil {
+		return nil, err
+	}
+	n.Cost = cost
+
+	return n, nil
+}
+
+// denseToFlat flattens m into a row-major []float64.
+func denseToFlat(m *mat64.Dense) []float64 {
+	rows, cols := m.Dims()
+	flat := make([]float64, rows*cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			flat[i*cols+j] = m.At(i, j)
+		}
+	}
+	return flat
+}
+
+// flatToDense rebuilds a row-major []float64 into a rows x cols Dense.
+func flatToDense(flat []float64, rows, cols int) *mat64.Dense {
+	m := mat64.NewDense(rows, cols, nil)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			m.Set(i, j, flat[i*cols+j])
+		}
+	}
+	return m
+}
+
+// activationToSpec identifies a's concrete type as an activationSpec.
+func activationToSpec(a Activation) (activationSpec, error) {
+	switch v := a.(type) {
+	case Sigmoid:
+		return activationSpec{Type: "sigmoid"}, nil
+	case Tanh:
+		return activationSpec{Type: "tanh"}, nil
+	case ReLU:
+		return activationSpec{Type: "relu"}, nil
+	case LeakyReLU:
+		return activationSpec{Type: "leaky_relu", Alpha: v.Alpha}, nil
+	case Softmax:
+		return activationSpec{Type: "softmax"}, nil
+	default:
+		return activationSpec{}, fmt.Errorf("Neural: unsupported activation type %T", a)
+	}
+}
+
+// specToActivation reconstructs the Activation identified by spec.
+func specToActivation(spec activationSpec) (Activation, error) {
+	switch spec.Type {
+	case "sigmoid":
+		return Sigmoid{}, nil
+	case "tanh":
+		return Tanh{}, nil
+	case "relu":
+		return ReLU{}, nil
+	case "leaky_relu":
+		return LeakyReLU{Alpha: spec.Alpha}, nil
+	case "softmax":
+		return Softmax{}, nil
+	default:
+		return nil, fmt.Errorf("Neural: unknown activation type %q", spec.Type)
+	}
+}
+
+// costToID identifies c's concrete type by name.
+func costToID(c Cost) (string, error) {
+	switch c.(type) {
+	case Quadratic:
+		return "quadratic", nil
+	case CrossEntropy:
+		return "cross_entropy", nil
+	case LogLikelihood:
+		return "log_likelihood", nil
+	default:
+		return "", fmt.Errorf("Neural: unsupported cost type %T", c)
+	}
+}
+
+// idToCost reconstructs the Cost identified by id.
+func idToCost(id string) (Cost, error) {
+	switch id {
+	case "quadratic":
+		return Quadratic{}, nil
+	case "cross_entropy":
+		return CrossEntropy{}, nil
+	case "log_likelihood":
+		return LogLikelihood{}, nil
+	default:
+		return nil, fmt.Errorf("Neural: unknown cost type %q", id)
+	}
+}
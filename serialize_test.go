@@ -0,0 +1,60 @@
+package Neural
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSaveLoad(t *testing.T) {
+	net := NewNetwork([]int{2, 3, 1}, NetworkParams{Cost: CrossEntropy{}})
+
+	var buf bytes.Buffer
+	if err := net.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.NumLayers != net.NumLayers {
+		t.Errorf("NumLayers = %v, want %v", loaded.NumLayers, net.NumLayers)
+	}
+	for i := range net.Weights {
+		x1, y1 := net.Weights[i].Dims()
+		x2, y2 := loaded.Weights[i].Dims()
+		if x1 != x2 || y1 != y2 {
+			t.Errorf("Weights[%d] dims = (%v,%v), want (%v,%v)", i, x2, y2, x1, y1)
+		}
+		if net.Weights[i].At(0, 0) != loaded.Weights[i].At(0, 0) {
+			t.Errorf("Weights[%d].At(0,0) = %v, want %v", i, loaded.Weights[i].At(0, 0), net.Weights[i].At(0, 0))
+		}
+	}
+	if _, ok := loaded.Cost.(CrossEntropy); !ok {
+		t.Errorf("Cost = %T, want CrossEntropy", loaded.Cost)
+	}
+}
+
+func TestSaveLoadJSON(t *testing.T) {
+	net := NewNetwork([]int{2, 3, 1}, NetworkParams{})
+
+	var buf bytes.Buffer
+	if err := net.SaveJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.NumLayers != net.NumLayers {
+		t.Errorf("NumLayers = %v, want %v", loaded.NumLayers, net.NumLayers)
+	}
+}
+
+func TestLoadRejectsBadMagic(t *testing.T) {
+	if _, err := Load(bytes.NewReader([]byte("not a model"))); err == nil {
+		t.Error("Load did not reject a file with bad magic bytes")
+	}
+}
@@ -0,0 +1,36 @@
+package Neural
+
+// RegType selects the weight regularization applied during training.
+type RegType int
+
+// Supported regularization types.
+const (
+	// NoReg disables regularization.
+	NoReg RegType = iota
+	// L1 applies eta*lambda/n*sign(w) weight decay.
+	L1
+	// L2 applies eta*lambda/n*w weight decay.
+	L2
+)
+
+// TrainConfig carries the knobs for Network.SGD: the learning rate,
+// regularization strength and type, the Optimizer driving the weight
+// updates, the epoch/batch sizing of the training run, and optional
+// progress reporting and early stopping.
+type TrainConfig struct {
+	Eta       float64
+	Lambda    float64
+	RegType   RegType
+	Optimizer Optimizer
+	Epochs    int
+	BatchSize int
+
+	// EpochCallback, when set, is invoked after every epoch with the
+	// training/validation cost and validation accuracy against test.
+	EpochCallback EpochCallback
+
+	// EarlyStopPatience, when positive, stops training once validation
+	// accuracy against test hasn't improved for this many consecutive
+	// epochs. 0 disables early stopping.
+	EarlyStopPatience int
+}